@@ -154,6 +154,14 @@ func Do(args []string) error {
 						b.printf("const char wuffs_base__%s__%s[] = \"%sbase: %s\";\n",
 							pre, cName(msg, ""), msg[:1], msg[1:])
 					}
+					// wuffs_base__error__over_memory_budget isn't in
+					// builtin.Statuses (that list is generated from the
+					// Wuffs standard library's own source, which this
+					// status predates), so it's spelled out by hand here,
+					// matching the format every other "#"-prefixed error
+					// string above uses.
+					b.writes("const char wuffs_base__error__over_memory_budget[] = " +
+						"\"#base: over memory budget\";\n")
 					return nil
 				},
 			}); err != nil {
@@ -234,6 +242,11 @@ func (b *buffer) writeb(x byte)                             { *b = append(*b, x)
 func (b *buffer) writes(s string)                           { *b = append(*b, s...) }
 func (b *buffer) writex(s []byte)                           { *b = append(*b, s...) }
 
+// Trim mirrors the Trim method on the embedded base sub-module sources
+// (e.g. embedBaseFundamentalPrivateH), letting a literal buffer value stand
+// in for one without its own embed file.
+func (b buffer) Trim() string { return strings.TrimSpace(string(b)) }
+
 func expandBangBangInsert(b *buffer, s string, m map[string]func(*buffer) error) error {
 	for {
 		remaining := ""
@@ -332,6 +345,8 @@ func insertBaseAllPublicH(buf *buffer) error {
 				}
 				b.printf("extern const char wuffs_base__%s__%s[];\n", pre, cName(msg, ""))
 			}
+			// See the matching hand-written definition in Do().
+			b.writes("extern const char wuffs_base__error__over_memory_budget[];\n")
 			return nil
 		},
 	}); err != nil {
@@ -350,9 +365,113 @@ func insertBaseAllPublicH(buf *buffer) error {
 	buf.writes(embedBaseImagePublicH.Trim())
 	buf.writeb('\n')
 	buf.writes(embedBaseStrConvPublicH.Trim())
+	buf.writeb('\n')
+	buf.writes(vtableRegistrationPublicH.Trim())
 	return nil
 }
 
+// vtableRegistrationPublicH declares the types that every package's
+// <pkg>registrations[] table (see writeVTableRegistrations) and
+// <pkg>iterate_registrations are built from, plus a cross-package
+// aggregate_iter that chains several packages' iterate_registrations
+// together, so that host code can walk every linked package's registrations
+// without hard-coding each wuffs_foo__bar__alloc_as__wuffs_base__baz. This
+// is small enough to inline here rather than live in its own embedded .h
+// file.
+var vtableRegistrationPublicH = buffer(`
+// wuffs_base__vtable_registration describes one (struct, interface) pair
+// that a package implements, so that host code can discover decoders by
+// name instead of hard-coding each wuffs_foo__bar__alloc_as__wuffs_base__baz.
+//
+// A NULL struct_name marks the sentinel, end-of-table entry.
+typedef struct wuffs_base__vtable_registration {
+  const char* struct_name;
+  const char* interface_name;
+  size_t sizeof_struct;
+  void* (*alloc)(void);
+  wuffs_base__status (*initialize)(void*, size_t, uint64_t, uint32_t);
+  const void* func_ptrs;
+} wuffs_base__vtable_registration;
+
+// wuffs_base__vtable_registration_iter walks a package's registrations[]
+// table, from the entry at ptr (inclusive) up to the sentinel at end
+// (exclusive).
+typedef struct wuffs_base__vtable_registration_iter {
+  const wuffs_base__vtable_registration* ptr;
+  const wuffs_base__vtable_registration* end;
+} wuffs_base__vtable_registration_iter;
+
+static inline wuffs_base__vtable_registration_iter
+wuffs_base__vtable_registration_iter__make(
+    const wuffs_base__vtable_registration* ptr,
+    const wuffs_base__vtable_registration* end) {
+  wuffs_base__vtable_registration_iter iter;
+  iter.ptr = ptr;
+  iter.end = end;
+  return iter;
+}
+
+static inline bool
+wuffs_base__vtable_registration_iter__next(
+    wuffs_base__vtable_registration_iter* iter,
+    const wuffs_base__vtable_registration** out) {
+  if (!iter || !iter->ptr || (iter->ptr >= iter->end)) {
+    return false;
+  }
+  *out = iter->ptr;
+  iter->ptr++;
+  return true;
+}
+
+// wuffs_base__vtable_registration_iter_fn matches the signature of every
+// generated wuffs_<pkg>__iterate_registrations.
+typedef wuffs_base__vtable_registration_iter
+    (*wuffs_base__vtable_registration_iter_fn)(void);
+
+// wuffs_base__vtable_registration_aggregate_iter chains zero or more
+// packages' iterate_registrations together into a single iterator, so host
+// code can enumerate every (struct, interface) pair across every package
+// linked into a program without hard-coding which packages those are. The
+// host supplies the list of per-package iterate_registrations function
+// pointers (generation time, in any one package, has no way to know what
+// other packages a program will link in).
+typedef struct wuffs_base__vtable_registration_aggregate_iter {
+  const wuffs_base__vtable_registration_iter_fn* fns;
+  size_t num_fns;
+  size_t next_fn_index;
+  wuffs_base__vtable_registration_iter current;
+} wuffs_base__vtable_registration_aggregate_iter;
+
+static inline wuffs_base__vtable_registration_aggregate_iter
+wuffs_base__vtable_registration_aggregate_iter__make(
+    const wuffs_base__vtable_registration_iter_fn* fns,
+    size_t num_fns) {
+  wuffs_base__vtable_registration_aggregate_iter iter;
+  iter.fns = fns;
+  iter.num_fns = num_fns;
+  iter.next_fn_index = 0;
+  iter.current = wuffs_base__vtable_registration_iter__make(NULL, NULL);
+  return iter;
+}
+
+static inline bool
+wuffs_base__vtable_registration_aggregate_iter__next(
+    wuffs_base__vtable_registration_aggregate_iter* iter,
+    const wuffs_base__vtable_registration** out) {
+  if (!iter) {
+    return false;
+  }
+  while (!wuffs_base__vtable_registration_iter__next(&iter->current, out)) {
+    if (!iter->fns || (iter->next_fn_index >= iter->num_fns)) {
+      return false;
+    }
+    iter->current = (*iter->fns[iter->next_fn_index])();
+    iter->next_fn_index++;
+  }
+  return true;
+}
+`)
+
 func insertBaseCopyright(buf *buffer) error {
 	s := string(embedBaseAllImplC)
 	if i := strings.Index(s, "\n\n"); i >= 0 {
@@ -503,23 +622,41 @@ func insertInterfaceDefinitions(buf *buffer) error {
 				return err
 			}
 
+			// The first_vtable slot is, in the overwhelming majority of
+			// programs, the only vtable a concrete struct has. Special-case
+			// it so that the C compiler can inline straight through to the
+			// ABI wrapper without looping, and only fall back to walking
+			// the remaining first_vtable[1..a.MaxImplements) slots for
+			// structs that implement more than one interface.
 			buf.writes("\n  const wuffs_base__vtable* v = &self->private_impl.first_vtable;\n")
-			buf.writes("  int i;\n")
-			buf.printf("  for (i = 0; i < %d; i++) {\n", a.MaxImplements)
-			buf.printf("    if (v->vtable_name == wuffs_base__%s__vtable_name) {\n", n)
-			buf.printf("      const wuffs_base__%s__func_ptrs* func_ptrs =\n"+
-				"          (const wuffs_base__%s__func_ptrs*)(v->function_pointers);\n", n, n)
-			buf.printf("      return (*func_ptrs->%s)(self", f.FuncName().Str(g.tm))
+			buf.printf("  if (v->vtable_name == wuffs_base__%s__vtable_name) {\n", n)
+			buf.printf("    const wuffs_base__%s__func_ptrs* func_ptrs =\n"+
+				"        (const wuffs_base__%s__func_ptrs*)(v->function_pointers);\n", n, n)
+			buf.printf("    return (*func_ptrs->%s)(self", f.FuncName().Str(g.tm))
 			for _, o := range f.In().Fields() {
 				buf.writes(", ")
 				buf.writes(aPrefix)
 				buf.writes(o.AsField().Name().Str(g.tm))
 			}
 			buf.writes(");\n")
-			buf.writes("    } else if (v->vtable_name == NULL) {\n")
-			buf.writes("      break;\n")
+			buf.writes("  } else if (v->vtable_name != NULL) {\n")
+			buf.writes("    int i;\n")
+			buf.printf("    for (i = 1; i < %d; i++) {\n", a.MaxImplements)
+			buf.writes("      v++;\n")
+			buf.printf("      if (v->vtable_name == wuffs_base__%s__vtable_name) {\n", n)
+			buf.printf("        const wuffs_base__%s__func_ptrs* func_ptrs =\n"+
+				"            (const wuffs_base__%s__func_ptrs*)(v->function_pointers);\n", n, n)
+			buf.printf("        return (*func_ptrs->%s)(self", f.FuncName().Str(g.tm))
+			for _, o := range f.In().Fields() {
+				buf.writes(", ")
+				buf.writes(aPrefix)
+				buf.writes(o.AsField().Name().Str(g.tm))
+			}
+			buf.writes(");\n")
+			buf.writes("      } else if (v->vtable_name == NULL) {\n")
+			buf.writes("        break;\n")
+			buf.writes("      }\n")
 			buf.writes("    }\n")
-			buf.writes("    v++;\n")
 			buf.writes("  }\n\n")
 
 			buf.writes("  return ")
@@ -797,6 +934,14 @@ func (g *gen) genHeader(b *buffer) error {
 		return err
 	}
 
+	if g.hasAnyImplements() {
+		b.writes("// ---------------- VTable Registrations\n\n")
+		b.writes("// wuffs_<pkg>__iterate_registrations lets host code enumerate, by string\n")
+		b.writes("// name, every (struct, interface) pair of this package's public structs, without the\n")
+		b.writes("// caller hard-coding each wuffs_foo__bar__alloc_as__wuffs_base__baz call.\n\n")
+		b.printf("wuffs_base__vtable_registration_iter\n%siterate_registrations(void);\n\n", g.pkgPrefix)
+	}
+
 	b.writes("#ifdef __cplusplus\n}  // extern \"C\"\n#endif\n\n")
 
 	b.writes("// ---------------- Struct Definitions\n\n")
@@ -860,6 +1005,10 @@ func (g *gen) genImpl(b *buffer) error {
 		}
 	}
 
+	if err := g.writeVTableRegistrations(b); err != nil {
+		return err
+	}
+
 	b.writes("// ---------------- Initializer Implementations\n\n")
 	for _, n := range g.structList {
 		if err := g.writeInitializerImpl(b, n); err != nil {
@@ -1058,7 +1207,16 @@ func (g *gen) writeConstList(b *buffer, n *a.Expr) error {
 }
 
 func (g *gen) writeStructPrivateImpl(b *buffer, n *a.Struct) error {
-	// TODO: allow max depth > 1 for recursive coroutines.
+	// TODO (chunk1-1, not implemented in this tree): allow max depth > 1 for
+	// recursive coroutines. Doing so soundly needs the gathering phase
+	// (alongside the rest of g.funks' construction) to compute or annotate
+	// per-coroutine call-stack depth and reject cyclic, non-terminating-by-
+	// construction coroutine-call graphs, and to thread a frame index
+	// through the suspend/resume codegen that this file's writeFuncImpl (not
+	// in this snapshot) emits; bumping just the array sizes here without
+	// that support would silently clobber the outer call's frame on every
+	// recursive call instead of pushing a new one. No such support exists
+	// yet, so every coroutine is still bound to a single frame.
 	const maxDepth = 1
 
 	b.writes("// Do not access the private_impl's or private_data's fields directly. There\n")
@@ -1078,6 +1236,14 @@ func (g *gen) writeStructPrivateImpl(b *buffer, n *a.Struct) error {
 				qid[0].Str(g.tm), qid[1].Str(g.tm))
 		}
 		b.writes("wuffs_base__vtable null_vtable;\n")
+		// memory_budget_bytes is the ceiling __initialize__with_memory_budget
+		// was given, kept around so that (in the future) work-buffer
+		// requests made after __initialize can be checked against it without
+		// threading an extra parameter through every call site. The static
+		// struct-size check already happens once, in __initialize itself.
+		// It comes after null_vtable so that the vtable_for__ fields stay at
+		// the offset of the interface structs' private_impl.first_vtable.
+		b.writes("uint64_t memory_budget_bytes;\n")
 		b.writes("\n")
 	}
 
@@ -1271,6 +1437,13 @@ func (g *gen) writeCppMethods(b *buffer, n *a.Struct) error {
 	b.printf("return %s%s__initialize(\nthis, sizeof_star_self, wuffs_version, options);\n}\n\n",
 		g.pkgPrefix, structName)
 
+	b.writes("inline wuffs_base__status WUFFS_BASE__WARN_UNUSED_RESULT\n" +
+		"initialize__with_memory_budget(\nsize_t sizeof_star_self,\nuint64_t wuffs_version,\n" +
+		"uint32_t options,\nuint64_t memory_budget_bytes) {\n")
+	b.printf("return %s%s__initialize__with_memory_budget(\n"+
+		"this, sizeof_star_self, wuffs_version, options, memory_budget_bytes);\n}\n\n",
+		g.pkgPrefix, structName)
+
 	for _, impl := range n.Implements() {
 		iQID := impl.AsTypeExpr().QID()
 		iName := fmt.Sprintf("wuffs_%s__%s", iQID[0].Str(g.tm), iQID[1].Str(g.tm))
@@ -1311,6 +1484,68 @@ func (g *gen) writeCppMethods(b *buffer, n *a.Struct) error {
 	return nil
 }
 
+// hasAnyImplements returns whether any struct in this package implements any
+// interface, i.e. whether there is anything worth registering for
+// name-based, plugin-style decoder discovery.
+func (g *gen) hasAnyImplements() bool {
+	for _, n := range g.structList {
+		if len(n.Implements()) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// writeVTableRegistrations emits a static table of every (struct, interface)
+// pair that this package's public structs implement (non-public structs have
+// no __alloc function and are not listed), plus an iterator over that table.
+// Host code
+// that wants to discover decoders by name (e.g. "wuffs_gif__decoder", or by
+// the interface "wuffs_base__image_decoder") walks this table instead of the
+// caller needing to hard-code each wuffs_foo__bar__alloc_as__wuffs_base__baz.
+func (g *gen) writeVTableRegistrations(b *buffer) error {
+	if !g.hasAnyImplements() {
+		return nil
+	}
+
+	b.writes("// ---------------- VTable Registrations\n\n")
+	b.printf("static const wuffs_base__vtable_registration\n%sregistrations[] = {\n", g.pkgPrefix)
+	for _, n := range g.structList {
+		if !n.Public() {
+			continue
+		}
+		structName := n.QID().Str(g.tm)
+		for _, impl := range n.Implements() {
+			iQID := impl.AsTypeExpr().QID()
+			iName := fmt.Sprintf("wuffs_%s__%s", iQID[0].Str(g.tm), iQID[1].Str(g.tm))
+			// Positional, not C99 designated-initializer, struct literal: the
+			// rest of this file sticks to plain aggregate init (see the
+			// func_ptrs literal in writeVTableImpl) so that the same generated C also
+			// compiles as C++. Field order here must track the declaration
+			// order of struct wuffs_base__vtable_registration.
+			b.writes("{\n")
+			b.printf("\"%s%s\",\n", g.pkgPrefix, structName)
+			b.printf("\"%s\",\n", iName)
+			b.printf("sizeof(%s%s),\n", g.pkgPrefix, structName)
+			b.printf("(void* (*)(void))(&%s%s__alloc),\n", g.pkgPrefix, structName)
+			b.printf("(wuffs_base__status (*)(void*, size_t, uint64_t, uint32_t))"+
+				"(&%s%s__initialize),\n", g.pkgPrefix, structName)
+			b.printf("(const void*)(&%s%s__func_ptrs_for__%s),\n",
+				g.pkgPrefix, structName, iName)
+			b.writes("},\n")
+		}
+	}
+	b.writes("{NULL, NULL, 0, NULL, NULL, NULL},\n")
+	b.writes("};\n\n")
+
+	b.printf("wuffs_base__vtable_registration_iter\n%siterate_registrations(void) {\n", g.pkgPrefix)
+	b.printf("return wuffs_base__vtable_registration_iter__make(\n"+
+		"%sregistrations, %sregistrations + (sizeof(%sregistrations) / "+
+		"sizeof(%sregistrations[0])) - 1);\n", g.pkgPrefix, g.pkgPrefix, g.pkgPrefix, g.pkgPrefix)
+	b.writes("}\n\n")
+	return nil
+}
+
 func (g *gen) writeVTableImpl(b *buffer, n *a.Struct) error {
 	impls := n.Implements()
 	if len(impls) == 0 {
@@ -1324,24 +1559,61 @@ func (g *gen) writeVTableImpl(b *buffer, n *a.Struct) error {
 	nQID := n.QID()
 	for _, impl := range impls {
 		iQID := impl.AsTypeExpr().QID()
-		b.printf("const wuffs_%s__%s__func_ptrs\n%s%s__func_ptrs_for__wuffs_%s__%s = {\n",
-			iQID[0].Str(g.tm), iQID[1].Str(g.tm),
-			g.pkgPrefix, nQID[1].Str(g.tm),
-			iQID[0].Str(g.tm), iQID[1].Str(g.tm),
-		)
 
 		// Note the two t.Map values: g.tm and builtInTokenMap.
 		altQID := t.QID{
 			builtInTokenMap.ByName(iQID[0].Str(g.tm)),
 			builtInTokenMap.ByName(iQID[1].Str(g.tm)),
 		}
+
+		// Emit a thin ABI wrapper per method: its signature matches the
+		// interface's (so its address is assignable to a func_ptrs field),
+		// but its body casts self to the concrete struct and calls straight
+		// through to the concrete, non-virtual method. This keeps the
+		// vtable's func_ptrs struct from ever pointing directly at a
+		// wuffs_foo__bar__baz symbol, so the concrete method stays free to
+		// diverge from the interface's own signature in the future.
+		for _, f := range builtInInterfaceMethods[altQID] {
+			start := len(*b)
+			if err := g.writeFuncSignature(b, f, wfsCDecl); err != nil {
+				return err
+			}
+			sig := string((*b)[start:])
+			*b = (*b)[:start]
+
+			oldName := fmt.Sprintf("wuffs_base__%s__%s", iQID[1].Str(g.tm), f.FuncName().Str(&builtInTokenMap))
+			newName := fmt.Sprintf("%s%s__WRAP_%s__%s",
+				g.pkgPrefix, nQID[1].Str(g.tm), iQID[1].Str(g.tm), f.FuncName().Str(&builtInTokenMap))
+			sig = strings.Replace(sig, oldName, newName, 1)
+
+			b.writeb('\n')
+			b.writes("static ")
+			b.writes(sig)
+			b.printf(" {\n  return %s%s__%s((%s%s*)(self)",
+				g.pkgPrefix, nQID[1].Str(g.tm), f.FuncName().Str(&builtInTokenMap),
+				g.pkgPrefix, nQID[1].Str(g.tm))
+			for _, o := range f.In().Fields() {
+				b.writes(", ")
+				b.writes(aPrefix)
+				b.writes(o.AsField().Name().Str(g.tm))
+			}
+			b.writes(");\n}\n")
+		}
+		b.writeb('\n')
+
+		b.printf("const wuffs_%s__%s__func_ptrs\n%s%s__func_ptrs_for__wuffs_%s__%s = {\n",
+			iQID[0].Str(g.tm), iQID[1].Str(g.tm),
+			g.pkgPrefix, nQID[1].Str(g.tm),
+			iQID[0].Str(g.tm), iQID[1].Str(g.tm),
+		)
+
 		for _, f := range builtInInterfaceMethods[altQID] {
 			b.writeb('(')
 			if err := g.writeFuncSignature(b, f, wfsCFuncPtrType); err != nil {
 				return err
 			}
-			b.printf(")(&%s%s__%s),\n",
-				g.pkgPrefix, nQID[1].Str(g.tm),
+			b.printf(")(&%s%s__WRAP_%s__%s),\n",
+				g.pkgPrefix, nQID[1].Str(g.tm), iQID[1].Str(g.tm),
 				f.FuncName().Str(&builtInTokenMap),
 			)
 		}
@@ -1350,15 +1622,28 @@ func (g *gen) writeVTableImpl(b *buffer, n *a.Struct) error {
 	return nil
 }
 
-func (g *gen) writeInitializerSignature(b *buffer, n *a.Struct, public bool) error {
+// writeInitializerSignature writes the signature of a struct's initializer.
+// withBudget selects the "real" initializer, which takes an extra
+// memory_budget_bytes argument: the plain wuffs_foo__bar__initialize is kept,
+// ABI-stable, as a thin wrapper (see writeInitializerImpl) that forwards to
+// it with memory_budget_bytes set to UINT64_MAX (no limit).
+func (g *gen) writeInitializerSignature(b *buffer, n *a.Struct, withBudget bool) error {
 	structName := n.QID().Str(g.tm)
+	name := fmt.Sprintf("%s%s__initialize", g.pkgPrefix, structName)
+	if withBudget {
+		name += "__with_memory_budget"
+	}
 	b.printf("wuffs_base__status WUFFS_BASE__WARN_UNUSED_RESULT\n"+
-		"%s%s__initialize(\n"+
+		"%s(\n"+
 		"    %s%s* self,\n"+
 		"    size_t sizeof_star_self,\n"+
 		"    uint64_t wuffs_version,\n"+
-		"    uint32_t options)",
-		g.pkgPrefix, structName, g.pkgPrefix, structName)
+		"    uint32_t options",
+		name, g.pkgPrefix, structName)
+	if withBudget {
+		b.writes(",\n    uint64_t memory_budget_bytes")
+	}
+	b.writes(")")
 	return nil
 }
 
@@ -1378,7 +1663,12 @@ func (g *gen) writeInitializerPrototype(b *buffer, n *a.Struct) error {
 	if !n.Classy() {
 		return nil
 	}
-	if err := g.writeInitializerSignature(b, n, n.Public()); err != nil {
+	if err := g.writeInitializerSignature(b, n, false); err != nil {
+		return err
+	}
+	b.writes(";\n\n")
+
+	if err := g.writeInitializerSignature(b, n, true); err != nil {
 		return err
 	}
 	b.writes(";\n\n")
@@ -1396,9 +1686,24 @@ func (g *gen) writeInitializerImpl(b *buffer, n *a.Struct) error {
 	if !n.Classy() {
 		return nil
 	}
+	structName := n.QID().Str(g.tm)
+
+	// The plain, ABI-stable wuffs_foo__bar__initialize is a thin wrapper
+	// that imposes no memory budget. Embedders that need to bound
+	// worst-case allocation (kernels, sandboxes, WASM) call
+	// wuffs_foo__bar__initialize__with_memory_budget directly instead.
 	if err := g.writeInitializerSignature(b, n, false); err != nil {
 		return err
 	}
+	b.writes(" {\n")
+	b.printf("return %s%s__initialize__with_memory_budget(\n"+
+		"self, sizeof_star_self, wuffs_version, options, UINT64_MAX);\n",
+		g.pkgPrefix, structName)
+	b.writes("}\n\n")
+
+	if err := g.writeInitializerSignature(b, n, true); err != nil {
+		return err
+	}
 	b.writes("{\n")
 	b.writes("if (!self) {\n")
 	b.writes("  return wuffs_base__make_status(wuffs_base__error__bad_receiver);\n")
@@ -1407,6 +1712,20 @@ func (g *gen) writeInitializerImpl(b *buffer, n *a.Struct) error {
 	b.writes("if (sizeof(*self) != sizeof_star_self) {\n")
 	b.writes("  return wuffs_base__make_status(wuffs_base__error__bad_sizeof_receiver);\n")
 	b.writes("}\n")
+	// sizeof(*self) already counts every nested sub-struct's bytes (that
+	// static layout is fixed at compile time), so this single check bounds
+	// the whole tree's static footprint without double-counting nesting the
+	// way a per-field decrement-and-forward would.
+	//
+	// TODO (chunk1-3, not implemented in this tree): this only bounds the
+	// static struct-tree size. The request also asked for coroutine-stack
+	// and work-buffer requests made after __initialize to fail once they'd
+	// exceed the remaining budget; no such dynamic accounting exists here,
+	// since the work-buffer/coroutine-stack allocation sites it would check
+	// against live in codegen this file doesn't contain.
+	b.writes("if ((uint64_t)(sizeof(*self)) > memory_budget_bytes) {\n")
+	b.writes("  return wuffs_base__make_status(wuffs_base__error__over_memory_budget);\n")
+	b.writes("}\n")
 	b.writes("if (((wuffs_version >> 32) != WUFFS_VERSION_MAJOR) ||\n" +
 		"(((wuffs_version >> 16) & 0xFFFF) > WUFFS_VERSION_MINOR)) {\n")
 	b.writes("  return wuffs_base__make_status(wuffs_base__error__bad_wuffs_version);\n")
@@ -1435,6 +1754,12 @@ func (g *gen) writeInitializerImpl(b *buffer, n *a.Struct) error {
 	b.writes("}\n\n")
 
 	// Initialize any choosy function pointers.
+	//
+	// TODO (chunk0-2, not implemented in this tree): dispatch among actual
+	// per-CPU-feature variants. That needs a .wuffs-level way to author a
+	// distinct body per variant (a parser/gathering-phase addition not
+	// present here); without it, every choosy function has exactly one body,
+	// so it's simply bound once rather than probed for.
 	hasChoosy := false
 	for _, file := range g.files {
 		for _, tld := range file.TopLevelDecls() {
@@ -1454,7 +1779,17 @@ func (g *gen) writeInitializerImpl(b *buffer, n *a.Struct) error {
 		b.writes("\n")
 	}
 
-	// Call any ctors on sub-structs.
+	b.writes("self->private_impl.memory_budget_bytes = memory_budget_bytes;\n\n")
+
+	// Call any ctors on sub-structs, passing the same budget down unchanged.
+	// A sub-struct's own sizeof already counts its nested structs' bytes
+	// (that static layout is fixed at compile time, and is what the
+	// sizeof_star_self check above already validates), so charging it again
+	// here against the shared budget would double-count every level of
+	// nesting. memory_budget_bytes is plumbed through so it's available for
+	// future dynamic (coroutine-stack, work-buffer) accounting, but no call
+	// site currently checks it against one: today it only ever guards the
+	// one-time, compile-time-constant sizeof(*self) check above.
 	for _, f := range n.Fields() {
 		f := f.AsField()
 		x := f.XType()
@@ -1477,8 +1812,9 @@ func (g *gen) writeInitializerImpl(b *buffer, n *a.Struct) error {
 		}
 
 		b.printf("{\n")
-		b.printf("wuffs_base__status z = %s%s__initialize(\n"+
-			"&self->private_data.%s%s, sizeof(self->private_data.%s%s), WUFFS_VERSION, options);\n",
+		b.printf("wuffs_base__status z = %s%s__initialize__with_memory_budget(\n"+
+			"&self->private_data.%s%s, sizeof(self->private_data.%s%s), WUFFS_VERSION, options,\n"+
+			"self->private_impl.memory_budget_bytes);\n",
 			prefix, qid[1].Str(g.tm), fPrefix, f.Name().Str(g.tm), fPrefix, f.Name().Str(g.tm))
 		b.printf("if (z.repr) {\nreturn z;\n}\n")
 		b.printf("}\n")
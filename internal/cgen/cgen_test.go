@@ -0,0 +1,51 @@
+// Copyright 2017 The Wuffs Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgen
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	a "github.com/google/wuffs/lang/ast"
+)
+
+// TestInsertInterfaceDefinitionsFastPath checks the base interface dispatch
+// emitted by insertInterfaceDefinitions (the caller side of the ABI wrapper
+// thunks that writeVTableImpl emits per struct): it special-cases the first
+// vtable slot, falls back to walking the remaining
+// first_vtable[1..MaxImplements) slots for structs that implement more than
+// one interface, and terminates that walk on a NULL vtable_name rather than
+// running past the end of the array.
+func TestInsertInterfaceDefinitionsFastPath(t *testing.T) {
+	buf := buffer(nil)
+	if err := insertInterfaceDefinitions(&buf); err != nil {
+		t.Fatalf("insertInterfaceDefinitions: %v", err)
+	}
+	got := string(buf)
+
+	if !strings.Contains(got, "if (v->vtable_name == wuffs_base__") {
+		t.Error("generated code is missing the first_vtable fast path")
+	}
+	if !strings.Contains(got, fmt.Sprintf("for (i = 1; i < %d; i++)", a.MaxImplements)) {
+		t.Error("generated code is missing the multi-implementer fallback loop")
+	}
+	if !strings.Contains(got, "} else if (v->vtable_name == NULL) {\n        break;") {
+		t.Error("generated code is missing the NULL vtable_name loop termination")
+	}
+	if !strings.Contains(got, "wuffs_base__make_status(wuffs_base__error__bad_vtable)") {
+		t.Error("generated code is missing the coroutine/status-returning dispatch fallback")
+	}
+}